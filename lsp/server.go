@@ -0,0 +1,317 @@
+// Package lsp implements a Language Server Protocol server that exposes a
+// udon notebook to LSP-aware editors: completion and go-to-definition for
+// [[wiki-links]], hover previews, workspace symbols, and a command for
+// creating new notes from within the editor.
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"udon/notes"
+
+	"github.com/tliron/glsp"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+	glspserver "github.com/tliron/glsp/server"
+)
+
+const serverName = "udon"
+
+// newCommand is the executeCommand name the editor invokes to create a note
+// and insert a link to it at the caller's cursor.
+const newCommand = "udon.new"
+
+// wikiLinkPattern matches [[Title]] references within a line of text.
+var wikiLinkPattern = regexp.MustCompile(`\[\[([^\]]+)\]\]`)
+
+// server holds the state shared across LSP handlers: the notebook's Store
+// and the text of every document the client currently has open.
+type server struct {
+	store *notes.Store
+
+	mu   sync.RWMutex
+	docs map[protocol.DocumentUri]string
+}
+
+// Run starts an LSP server over stdio backed by store, blocking until the
+// client disconnects or the process is asked to shut down.
+func Run(store *notes.Store) error {
+	s := &server{store: store, docs: make(map[protocol.DocumentUri]string)}
+
+	handler := protocol.Handler{
+		Initialize:              s.initialize,
+		Shutdown:                s.shutdown,
+		TextDocumentDidOpen:     s.didOpen,
+		TextDocumentDidChange:   s.didChange,
+		TextDocumentDidClose:    s.didClose,
+		TextDocumentCompletion:  s.completion,
+		TextDocumentDefinition:  s.definition,
+		TextDocumentHover:       s.hover,
+		WorkspaceSymbol:         s.workspaceSymbol,
+		WorkspaceExecuteCommand: s.executeCommand,
+	}
+
+	srv := glspserver.NewServer(&handler, serverName, false)
+	return srv.RunStdio()
+}
+
+func (s *server) initialize(ctx *glsp.Context, params *protocol.InitializeParams) (any, error) {
+	trueVal := true
+	capabilities := protocol.ServerCapabilities{
+		TextDocumentSync: protocol.TextDocumentSyncKindFull,
+		CompletionProvider: &protocol.CompletionOptions{
+			TriggerCharacters: []string{"["},
+		},
+		DefinitionProvider:      &trueVal,
+		HoverProvider:           &trueVal,
+		WorkspaceSymbolProvider: &trueVal,
+		ExecuteCommandProvider: &protocol.ExecuteCommandOptions{
+			Commands: []string{newCommand},
+		},
+	}
+
+	return protocol.InitializeResult{
+		Capabilities: capabilities,
+		ServerInfo: &protocol.InitializeResultServerInfo{
+			Name: serverName,
+		},
+	}, nil
+}
+
+func (s *server) shutdown(ctx *glsp.Context) error {
+	return nil
+}
+
+func (s *server) didOpen(ctx *glsp.Context, params *protocol.DidOpenTextDocumentParams) error {
+	s.mu.Lock()
+	s.docs[params.TextDocument.URI] = params.TextDocument.Text
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *server) didChange(ctx *glsp.Context, params *protocol.DidChangeTextDocumentParams) error {
+	if len(params.ContentChanges) == 0 {
+		return nil
+	}
+	// Full sync: the last change event carries the document's whole text.
+	if change, ok := params.ContentChanges[len(params.ContentChanges)-1].(protocol.TextDocumentContentChangeEventWhole); ok {
+		s.mu.Lock()
+		s.docs[params.TextDocument.URI] = change.Text
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+func (s *server) didClose(ctx *glsp.Context, params *protocol.DidCloseTextDocumentParams) error {
+	s.mu.Lock()
+	delete(s.docs, params.TextDocument.URI)
+	s.mu.Unlock()
+	return nil
+}
+
+// lineAt returns the line of doc text at a zero-based line number.
+func lineAt(text string, line uint32) string {
+	lines := strings.Split(text, "\n")
+	if int(line) >= len(lines) {
+		return ""
+	}
+	return lines[line]
+}
+
+// wikiLinkAt returns the title of the [[wiki-link]] on line that contains
+// character, if any.
+func wikiLinkAt(line string, character uint32) (string, bool) {
+	for _, loc := range wikiLinkPattern.FindAllStringSubmatchIndex(line, -1) {
+		start, end := loc[0], loc[1]
+		if int(character) >= start && int(character) <= end {
+			return line[loc[2]:loc[3]], true
+		}
+	}
+	return "", false
+}
+
+func (s *server) doc(uri protocol.DocumentUri) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.docs[uri]
+}
+
+func (s *server) completion(ctx *glsp.Context, params *protocol.CompletionParams) (any, error) {
+	line := lineAt(s.doc(params.TextDocument.URI), params.Position.Line)
+	prefix := linkPrefix(line, params.Position.Character)
+	if prefix == "" && !strings.HasSuffix(line[:min(len(line), int(params.Position.Character))], "[[") {
+		return nil, nil
+	}
+
+	allNotes, err := s.store.GetNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	var items []protocol.CompletionItem
+	kind := protocol.CompletionItemKindReference
+	for _, n := range allNotes {
+		if prefix != "" && !strings.Contains(strings.ToLower(n.Title), strings.ToLower(prefix)) {
+			continue
+		}
+		items = append(items, protocol.CompletionItem{
+			Label: n.Title,
+			Kind:  &kind,
+		})
+	}
+	return items, nil
+}
+
+// linkPrefix returns the partial title typed so far inside an open
+// "[[partial" on line up to character, or "" if the cursor isn't inside one.
+func linkPrefix(line string, character uint32) string {
+	upTo := line[:min(len(line), int(character))]
+	idx := strings.LastIndex(upTo, "[[")
+	if idx == -1 {
+		return ""
+	}
+	return upTo[idx+2:]
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func (s *server) definition(ctx *glsp.Context, params *protocol.DefinitionParams) (any, error) {
+	line := lineAt(s.doc(params.TextDocument.URI), params.Position.Line)
+	title, ok := wikiLinkAt(line, params.Position.Character)
+	if !ok {
+		return nil, nil
+	}
+
+	note, err := s.store.Load(title)
+	if err != nil {
+		return nil, nil // No definition for a link to a note that doesn't exist
+	}
+
+	return protocol.Location{
+		URI: protocol.DocumentUri("file://" + note.Path),
+		Range: protocol.Range{
+			Start: protocol.Position{Line: 0, Character: 0},
+			End:   protocol.Position{Line: 0, Character: 0},
+		},
+	}, nil
+}
+
+func (s *server) hover(ctx *glsp.Context, params *protocol.HoverParams) (*protocol.Hover, error) {
+	line := lineAt(s.doc(params.TextDocument.URI), params.Position.Line)
+	title, ok := wikiLinkAt(line, params.Position.Character)
+	if !ok {
+		return nil, nil
+	}
+
+	note, err := s.store.Load(title)
+	if err != nil {
+		return nil, nil
+	}
+
+	return &protocol.Hover{
+		Contents: protocol.MarkupContent{
+			Kind:  protocol.MarkupKindMarkdown,
+			Value: firstParagraph(note.Content),
+		},
+	}, nil
+}
+
+// firstParagraph returns the text up to the first blank line, for use as a
+// hover preview.
+func firstParagraph(content string) string {
+	if idx := strings.Index(content, "\n\n"); idx != -1 {
+		return strings.TrimSpace(content[:idx])
+	}
+	return strings.TrimSpace(content)
+}
+
+func (s *server) workspaceSymbol(ctx *glsp.Context, params *protocol.WorkspaceSymbolParams) ([]protocol.SymbolInformation, error) {
+	allNotes, err := s.store.GetNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	kind := protocol.SymbolKindFile
+	symbols := make([]protocol.SymbolInformation, 0, len(allNotes))
+	for _, n := range allNotes {
+		if params.Query != "" && !strings.Contains(strings.ToLower(n.Title), strings.ToLower(params.Query)) {
+			continue
+		}
+		symbols = append(symbols, protocol.SymbolInformation{
+			Name: n.Title,
+			Kind: kind,
+			Location: protocol.Location{
+				URI: protocol.DocumentUri("file://" + n.Path),
+			},
+		})
+	}
+	return symbols, nil
+}
+
+// executeCommand handles the "udon.new" command: create a note titled by
+// the first argument and, if insertLinkAtLocation is given as the second
+// argument, apply a workspace edit inserting a [[link]] to it there.
+func (s *server) executeCommand(ctx *glsp.Context, params *protocol.ExecuteCommandParams) (any, error) {
+	if params.Command != newCommand || len(params.Arguments) == 0 {
+		return nil, fmt.Errorf("unsupported command %q", params.Command)
+	}
+
+	title, ok := params.Arguments[0].(string)
+	if !ok || strings.TrimSpace(title) == "" {
+		return nil, fmt.Errorf("udon.new requires a note title argument")
+	}
+
+	note, err := notes.NewNote(s.store, notes.NewNoteOpts{Title: title})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(params.Arguments) < 2 {
+		return note.Path, nil
+	}
+
+	loc, ok := decodeLocation(params.Arguments[1])
+	if !ok {
+		return note.Path, nil
+	}
+
+	edit := protocol.WorkspaceEdit{
+		Changes: map[protocol.DocumentUri][]protocol.TextEdit{
+			loc.URI: {{
+				Range:   loc.Range,
+				NewText: fmt.Sprintf("[[%s]]", title),
+			}},
+		},
+	}
+
+	// workspace/applyEdit is a server-to-client request, not a
+	// notification: the client replies with whether it applied the edit.
+	var result protocol.ApplyWorkspaceEditResponse
+	ctx.Call(protocol.ServerWorkspaceApplyEdit, protocol.ApplyWorkspaceEditParams{Edit: edit}, &result)
+	if !result.Applied {
+		return note.Path, fmt.Errorf("client did not apply workspace edit to insert link")
+	}
+
+	return note.Path, nil
+}
+
+// decodeLocation converts the loosely-typed JSON argument sent by the
+// client's executeCommand call into a protocol.Location.
+func decodeLocation(arg any) (protocol.Location, bool) {
+	raw, err := json.Marshal(arg)
+	if err != nil {
+		return protocol.Location{}, false
+	}
+	var loc protocol.Location
+	if err := json.Unmarshal(raw, &loc); err != nil || loc.URI == "" {
+		return protocol.Location{}, false
+	}
+	return loc, true
+}
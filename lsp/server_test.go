@@ -0,0 +1,79 @@
+package lsp
+
+import (
+	"testing"
+	"udon/notes"
+
+	protocol "github.com/tliron/glsp/protocol_3_16"
+)
+
+func newTestServer(t *testing.T) *server {
+	t.Helper()
+
+	store := &notes.Store{}
+	if err := store.InitAt(t.TempDir()); err != nil {
+		t.Fatalf("InitAt: %v", err)
+	}
+	if err := store.Save(notes.Note{Title: "Target", Content: "the destination note"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	return &server{store: store, docs: make(map[protocol.DocumentUri]string)}
+}
+
+func TestHoverResolvesWikiLink(t *testing.T) {
+	s := newTestServer(t)
+	uri := protocol.DocumentUri("file:///source.md")
+	s.docs[uri] = "see [[Target]] for details"
+
+	result, err := s.hover(nil, &protocol.HoverParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: uri},
+			Position:     protocol.Position{Line: 0, Character: 5},
+		},
+	})
+	if err != nil {
+		t.Fatalf("hover: %v", err)
+	}
+	if result == nil {
+		t.Fatalf("hover: got nil, want a hover result for a link to an existing note")
+	}
+	content, ok := result.Contents.(protocol.MarkupContent)
+	if !ok || content.Value != "the destination note" {
+		t.Fatalf("hover contents: got %#v, want the target note's first paragraph", result.Contents)
+	}
+}
+
+func TestHoverNoLinkAtCursor(t *testing.T) {
+	s := newTestServer(t)
+	uri := protocol.DocumentUri("file:///source.md")
+	s.docs[uri] = "no links on this line"
+
+	result, err := s.hover(nil, &protocol.HoverParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: uri},
+			Position:     protocol.Position{Line: 0, Character: 5},
+		},
+	})
+	if err != nil {
+		t.Fatalf("hover: %v", err)
+	}
+	if result != nil {
+		t.Fatalf("hover: got %#v, want nil when the cursor isn't on a link", result)
+	}
+}
+
+func TestWorkspaceSymbolFiltersByQuery(t *testing.T) {
+	s := newTestServer(t)
+	if err := s.store.Save(notes.Note{Title: "Other", Content: "unrelated"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	symbols, err := s.workspaceSymbol(nil, &protocol.WorkspaceSymbolParams{Query: "targ"})
+	if err != nil {
+		t.Fatalf("workspaceSymbol: %v", err)
+	}
+	if len(symbols) != 1 || symbols[0].Name != "Target" {
+		t.Fatalf("workspaceSymbol: got %v, want [Target]", symbols)
+	}
+}
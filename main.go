@@ -5,17 +5,50 @@ package main
 
 import (
 	"log"
+	"os"
+	"udon/lsp"
 	"udon/notes"
 	"udon/tui"
 )
 
 func main() {
-	store := &notes.Store{}
-	if err := store.Init(); err != nil {
-		log.Fatalf("failed to initialize store: %v", err)
+	workspace := notes.NewWorkspace()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		log.Fatalf("failed to get working directory: %v", err)
+	}
+
+	nb, err := workspace.Locate(cwd)
+	if err != nil {
+		// No .udon notebook found above cwd: fall back to the single
+		// default notebook at ~/Documents/udon.
+		store := &notes.Store{}
+		if err := store.Init(); err != nil {
+			log.Fatalf("failed to initialize store: %v", err)
+		}
+
+		if len(os.Args) > 1 && os.Args[1] == "lsp" {
+			if err := lsp.Run(store); err != nil {
+				log.Fatalf("LSP server exited with error: %v", err)
+			}
+			return
+		}
+
+		if err := tui.Run(store); err != nil {
+			log.Fatalf("TUI exited with error: %v", err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "lsp" {
+		if err := lsp.Run(nb.Store); err != nil {
+			log.Fatalf("LSP server exited with error: %v", err)
+		}
+		return
 	}
 
-	if err := tui.Run(store); err != nil {
+	if err := tui.RunWithWorkspace(workspace, nb.Name); err != nil {
 		log.Fatalf("TUI exited with error: %v", err)
 	}
 }
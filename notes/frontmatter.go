@@ -0,0 +1,84 @@
+package notes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// frontMatterDelim marks the start and end of a note's YAML front matter
+// block.
+const frontMatterDelim = "---"
+
+// splitFrontMatter separates a leading YAML front matter block from the
+// rest of content. If content has no front matter, it returns a nil map and
+// content unchanged.
+func splitFrontMatter(content string) (map[string]any, string, error) {
+	lines := strings.SplitAfter(content, "\n")
+	if len(lines) == 0 || strings.TrimRight(lines[0], "\n") != frontMatterDelim {
+		return nil, content, nil
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], "\n") != frontMatterDelim {
+			continue
+		}
+
+		raw := strings.Join(lines[1:i], "")
+		body := strings.Join(lines[i+1:], "")
+
+		var fm map[string]any
+		if err := yaml.Unmarshal([]byte(raw), &fm); err != nil {
+			return nil, content, fmt.Errorf("error parsing front matter: %w", err)
+		}
+		return fm, body, nil
+	}
+
+	// Unterminated front matter block: treat the whole file as plain content.
+	return nil, content, nil
+}
+
+// tagsFromFrontMatter extracts a "tags" key from front matter, accepting
+// either a YAML sequence or a single string value. Non-string values are
+// ignored.
+func tagsFromFrontMatter(fm map[string]any) []string {
+	raw, ok := fm["tags"]
+	if !ok {
+		return nil
+	}
+
+	switch v := raw.(type) {
+	case []any:
+		tags := make([]string, 0, len(v))
+		for _, t := range v {
+			if s, ok := t.(string); ok {
+				tags = append(tags, s)
+			}
+		}
+		return tags
+	case string:
+		return []string{v}
+	default:
+		return nil
+	}
+}
+
+// renderFrontMatter builds a YAML front matter block for a newly created
+// note.
+func renderFrontMatter(title string, created time.Time, tags []string) string {
+	var b strings.Builder
+	b.WriteString(frontMatterDelim + "\n")
+	fmt.Fprintf(&b, "title: %q\n", title)
+	fmt.Fprintf(&b, "created: %s\n", created.Format(time.RFC3339))
+
+	quoted := make([]string, len(tags))
+	for i, t := range tags {
+		quoted[i] = fmt.Sprintf("%q", t)
+	}
+	fmt.Fprintf(&b, "tags: [%s]\n", strings.Join(quoted, ", "))
+
+	b.WriteString(frontMatterDelim + "\n\n")
+	return b.String()
+}
@@ -0,0 +1,108 @@
+package notes
+
+import (
+	"github.com/sahilm/fuzzy"
+)
+
+// Match is a single fuzzy-search result: the matched note together with the
+// rune indexes (into Title, falling back to Content) that should be
+// highlighted in the UI.
+type Match struct {
+	Note           Note
+	MatchedIndexes []int
+	MatchedInBody  bool
+	Score          int
+}
+
+// FilterOpts controls how FilterNotes ranks and trims results.
+type FilterOpts struct {
+	// Limit caps the number of matches returned. Zero means unlimited.
+	Limit int
+}
+
+// fuzzySource adapts a []Note to fuzzy.Source, searching titles first and
+// falling back to content so a query can match either.
+type fuzzySource struct {
+	notes  []Note
+	inBody bool
+}
+
+func (s fuzzySource) String(i int) string {
+	if s.inBody {
+		return s.notes[i].Content
+	}
+	return s.notes[i].Title
+}
+
+func (s fuzzySource) Len() int {
+	return len(s.notes)
+}
+
+// FilterNotes ranks every note in the Store against query using fuzzy
+// subsequence matching, searching titles first and falling back to note
+// bodies for anything the title pass didn't match. Results are sorted by
+// descending score, the same ordering fuzzy.Find itself produces.
+func FilterNotes(store *Store, query string, opts FilterOpts) ([]Match, error) {
+	allNotes, err := store.GetNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	if query == "" {
+		matches := make([]Match, 0, len(allNotes))
+		for _, n := range allNotes {
+			matches = append(matches, Match{Note: n})
+		}
+		return applyLimit(matches, opts.Limit), nil
+	}
+
+	titleMatches := fuzzy.FindFrom(query, fuzzySource{notes: allNotes})
+
+	matched := make(map[int]bool, len(titleMatches))
+	results := make([]Match, 0, len(titleMatches))
+	for _, m := range titleMatches {
+		matched[m.Index] = true
+		results = append(results, Match{
+			Note:           allNotes[m.Index],
+			MatchedIndexes: m.MatchedIndexes,
+			Score:          m.Score,
+		})
+	}
+
+	remaining := make([]Note, 0, len(allNotes)-len(matched))
+	remainingIdx := make([]int, 0, len(allNotes)-len(matched))
+	for i, n := range allNotes {
+		if !matched[i] {
+			remaining = append(remaining, n)
+			remainingIdx = append(remainingIdx, i)
+		}
+	}
+
+	bodyMatches := fuzzy.FindFrom(query, fuzzySource{notes: remaining, inBody: true})
+	for _, m := range bodyMatches {
+		results = append(results, Match{
+			Note:           remaining[m.Index],
+			MatchedIndexes: m.MatchedIndexes,
+			MatchedInBody:  true,
+			Score:          m.Score,
+		})
+	}
+
+	return applyLimit(sortByScore(results), opts.Limit), nil
+}
+
+func sortByScore(matches []Match) []Match {
+	for i := 1; i < len(matches); i++ {
+		for j := i; j > 0 && matches[j].Score > matches[j-1].Score; j-- {
+			matches[j], matches[j-1] = matches[j-1], matches[j]
+		}
+	}
+	return matches
+}
+
+func applyLimit(matches []Match, limit int) []Match {
+	if limit > 0 && len(matches) > limit {
+		return matches[:limit]
+	}
+	return matches
+}
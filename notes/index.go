@@ -0,0 +1,267 @@
+package notes
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// indexFilename is the name of the SQLite database that backs a notebook's
+// search index. It lives alongside the notes themselves.
+const indexFilename = ".udon-index.db"
+
+// Index is a persistent, incrementally-updated full-text index over a
+// notebook's markdown files. It is backed by SQLite's FTS5 extension and
+// keeps enough metadata (mtimes, word counts, tags) to answer filtered
+// queries without re-reading files from disk.
+type Index struct {
+	db *sql.DB
+}
+
+// NoteFindOpts controls which notes Store.Query returns and in what order.
+type NoteFindOpts struct {
+	// Query is matched against title and body using FTS5 MATCH syntax. An
+	// empty Query matches every note.
+	Query string
+	// Tags restricts results to notes carrying all of the given tags.
+	Tags []string
+	// After and Before, when non-nil, bound the note's CreatedAt.
+	After  *time.Time
+	Before *time.Time
+	// SortBy is one of "created_at", "updated_at", or "word_count". It
+	// defaults to "updated_at".
+	SortBy string
+	Limit  int
+	Offset int
+}
+
+// openIndex opens (creating if necessary) the SQLite index database at path
+// and ensures its schema is up to date.
+func openIndex(path string) (*Index, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening index %q: %w", path, err)
+	}
+
+	idx := &Index{db: db}
+	if err := idx.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return idx, nil
+}
+
+// migrate creates the index schema if it does not already exist.
+func (idx *Index) migrate() error {
+	const schema = `
+CREATE VIRTUAL TABLE IF NOT EXISTS notes_fts USING fts5(
+	filename UNINDEXED,
+	title,
+	body
+);
+CREATE TABLE IF NOT EXISTS notes_meta (
+	filename   TEXT PRIMARY KEY,
+	title      TEXT NOT NULL,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL,
+	word_count INTEGER NOT NULL,
+	tags       TEXT NOT NULL DEFAULT '',
+	mtime      INTEGER NOT NULL
+);
+`
+	_, err := idx.db.Exec(schema)
+	if err != nil {
+		return fmt.Errorf("error creating index schema: %w", err)
+	}
+	return nil
+}
+
+// Close releases the underlying database handle.
+func (idx *Index) Close() error {
+	return idx.db.Close()
+}
+
+// upsert inserts or replaces the indexed row for a note, keyed by filename.
+func (idx *Index) upsert(filename string, note Note, mtime time.Time, tags []string) error {
+	tx, err := idx.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning index transaction: %w", err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM notes_fts WHERE filename = ?`, filename); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("error clearing fts row for %q: %w", filename, err)
+	}
+	if _, err := tx.Exec(
+		`INSERT INTO notes_fts (filename, title, body) VALUES (?, ?, ?)`,
+		filename, note.Title, note.Content,
+	); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("error indexing %q: %w", filename, err)
+	}
+
+	wordCount := len(strings.Fields(note.Content))
+	if _, err := tx.Exec(
+		`INSERT INTO notes_meta (filename, title, created_at, updated_at, word_count, tags, mtime)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(filename) DO UPDATE SET
+			title=excluded.title,
+			updated_at=excluded.updated_at,
+			word_count=excluded.word_count,
+			tags=excluded.tags,
+			mtime=excluded.mtime`,
+		filename, note.Title, note.ModTime, note.ModTime, wordCount, strings.Join(tags, ","), mtime.Unix(),
+	); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("error updating metadata for %q: %w", filename, err)
+	}
+
+	return tx.Commit()
+}
+
+// remove deletes the indexed row for filename, if any.
+func (idx *Index) remove(filename string) error {
+	if _, err := idx.db.Exec(`DELETE FROM notes_fts WHERE filename = ?`, filename); err != nil {
+		return fmt.Errorf("error removing %q from index: %w", filename, err)
+	}
+	if _, err := idx.db.Exec(`DELETE FROM notes_meta WHERE filename = ?`, filename); err != nil {
+		return fmt.Errorf("error removing %q metadata: %w", filename, err)
+	}
+	return nil
+}
+
+// mtime returns the indexed mtime for filename, and whether a row exists.
+func (idx *Index) mtime(filename string) (time.Time, bool, error) {
+	var unixSeconds int64
+	err := idx.db.QueryRow(`SELECT mtime FROM notes_meta WHERE filename = ?`, filename).Scan(&unixSeconds)
+	if err == sql.ErrNoRows {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("error reading mtime for %q: %w", filename, err)
+	}
+	return time.Unix(unixSeconds, 0), true, nil
+}
+
+// query runs opts against the index and returns matching filenames in order.
+func (idx *Index) query(opts NoteFindOpts) ([]string, error) {
+	sortCol := "updated_at"
+	switch opts.SortBy {
+	case "created_at", "word_count":
+		sortCol = opts.SortBy
+	}
+
+	var (
+		where []string
+		args  []any
+	)
+
+	if strings.TrimSpace(opts.Query) != "" {
+		where = append(where, `notes_meta.filename IN (SELECT filename FROM notes_fts WHERE notes_fts MATCH ?)`)
+		args = append(args, opts.Query)
+	}
+	for _, tag := range opts.Tags {
+		where = append(where, `(',' || notes_meta.tags || ',') LIKE ?`)
+		args = append(args, "%,"+tag+",%")
+	}
+	if opts.After != nil {
+		where = append(where, `notes_meta.created_at >= ?`)
+		args = append(args, *opts.After)
+	}
+	if opts.Before != nil {
+		where = append(where, `notes_meta.created_at <= ?`)
+		args = append(args, *opts.Before)
+	}
+
+	q := `SELECT filename FROM notes_meta`
+	if len(where) > 0 {
+		q += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	q += fmt.Sprintf(` ORDER BY %s DESC`, sortCol)
+
+	if opts.Limit > 0 {
+		q += fmt.Sprintf(` LIMIT %d OFFSET %d`, opts.Limit, opts.Offset)
+	}
+
+	rows, err := idx.db.Query(q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying index: %w", err)
+	}
+	defer rows.Close()
+
+	var filenames []string
+	for rows.Next() {
+		var filename string
+		if err := rows.Scan(&filename); err != nil {
+			return nil, fmt.Errorf("error scanning index row: %w", err)
+		}
+		filenames = append(filenames, filename)
+	}
+	return filenames, rows.Err()
+}
+
+// Reindex walks notesDir and upserts any note whose file mtime is newer than
+// what the index has on record, bringing the index up to date with the
+// filesystem. It is safe to call repeatedly; unchanged notes are skipped.
+func (s *Store) Reindex() error {
+	entries, err := os.ReadDir(s.notesDir)
+	if err != nil {
+		return fmt.Errorf("error reading notes directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".md" {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue // Skip files with unreadable metadata
+		}
+
+		indexed, ok, err := s.index.mtime(entry.Name())
+		if err != nil {
+			return err
+		}
+		if ok && info.ModTime().Unix() <= indexed.Unix() {
+			continue // Already up to date
+		}
+
+		title := strings.TrimSuffix(entry.Name(), ".md")
+		note, err := s.Load(title)
+		if err != nil {
+			continue // Skip notes that vanished or can't be read
+		}
+
+		if err := s.index.upsert(entry.Name(), *note, info.ModTime(), note.Tags); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Query returns notes matching opts, using the persistent index rather than
+// scanning every note on disk.
+func (s *Store) Query(opts NoteFindOpts) ([]Note, error) {
+	filenames, err := s.index.query(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := make([]Note, 0, len(filenames))
+	for _, filename := range filenames {
+		title := strings.TrimSuffix(filename, ".md")
+		note, err := s.Load(title)
+		if err != nil {
+			continue // Skip notes that were deleted since the index was built
+		}
+		notes = append(notes, *note)
+	}
+	return notes, nil
+}
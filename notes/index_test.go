@@ -0,0 +1,99 @@
+package notes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIndexUpsertTags(t *testing.T) {
+	idx, err := openIndex(filepath.Join(t.TempDir(), indexFilename))
+	if err != nil {
+		t.Fatalf("openIndex: %v", err)
+	}
+	defer idx.Close()
+
+	note := Note{Title: "Recipe", Content: "flour, sugar, eggs"}
+	if err := idx.upsert("recipe.md", note, time.Now(), []string{"cooking", "baking"}); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+
+	got, err := idx.query(NoteFindOpts{Tags: []string{"baking"}})
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(got) != 1 || got[0] != "recipe.md" {
+		t.Fatalf("query by tag: got %v, want [recipe.md]", got)
+	}
+
+	if got, err := idx.query(NoteFindOpts{Tags: []string{"gardening"}}); err != nil {
+		t.Fatalf("query: %v", err)
+	} else if len(got) != 0 {
+		t.Fatalf("query by unrelated tag: got %v, want none", got)
+	}
+}
+
+func TestIndexQueryFullText(t *testing.T) {
+	idx, err := openIndex(filepath.Join(t.TempDir(), indexFilename))
+	if err != nil {
+		t.Fatalf("openIndex: %v", err)
+	}
+	defer idx.Close()
+
+	if err := idx.upsert("recipe.md", Note{Title: "Recipe", Content: "flour, sugar, eggs"}, time.Now(), nil); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	if err := idx.upsert("todo.md", Note{Title: "Todo", Content: "buy milk"}, time.Now(), nil); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+
+	got, err := idx.query(NoteFindOpts{Query: "flour"})
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(got) != 1 || got[0] != "recipe.md" {
+		t.Fatalf("MATCH query: got %v, want [recipe.md]", got)
+	}
+}
+
+func TestStoreGetNotesUsesIndex(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "existing.md"), []byte("hello"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	s := &Store{}
+	if err := s.InitAt(dir); err != nil {
+		t.Fatalf("InitAt: %v", err)
+	}
+
+	got, err := s.GetNotes()
+	if err != nil {
+		t.Fatalf("GetNotes: %v", err)
+	}
+	if len(got) != 1 || got[0].Title != "existing" {
+		t.Fatalf("GetNotes: got %v, want one note titled \"existing\"", got)
+	}
+}
+
+func TestIndexUpsertNoTags(t *testing.T) {
+	idx, err := openIndex(filepath.Join(t.TempDir(), indexFilename))
+	if err != nil {
+		t.Fatalf("openIndex: %v", err)
+	}
+	defer idx.Close()
+
+	note := Note{Title: "Untagged", Content: "no front matter here"}
+	if err := idx.upsert("untagged.md", note, time.Now(), nil); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+
+	got, err := idx.query(NoteFindOpts{Tags: []string{"cooking"}})
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("query by tag against untagged note: got %v, want none", got)
+	}
+}
@@ -0,0 +1,161 @@
+package notes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// wikiLinkPattern matches [[Title]] references within note content.
+var wikiLinkPattern = regexp.MustCompile(`\[\[([^\]]+)\]\]`)
+
+// mdLinkPattern matches standard markdown links to other notes,
+// e.g. [some text](other-note.md).
+var mdLinkPattern = regexp.MustCompile(`\[[^\]]*\]\(([^)]+\.md)\)`)
+
+// LinkGraph holds the forward and backward edges between notes, keyed by
+// note title, as derived from [[wiki-links]] and markdown [text](path.md)
+// references.
+type LinkGraph struct {
+	forward  map[string][]string
+	backward map[string][]string
+}
+
+// parseLinks extracts every note title that content links to, via either
+// [[wiki-link]] or markdown [text](path.md) syntax.
+func parseLinks(content string) []string {
+	var links []string
+
+	for _, m := range wikiLinkPattern.FindAllStringSubmatch(content, -1) {
+		links = append(links, strings.TrimSpace(m[1]))
+	}
+	for _, m := range mdLinkPattern.FindAllStringSubmatch(content, -1) {
+		title := strings.TrimSuffix(filepath.Base(m[1]), ".md")
+		links = append(links, title)
+	}
+
+	return links
+}
+
+// buildLinkGraph derives a LinkGraph from every note in allNotes.
+func buildLinkGraph(allNotes []Note) *LinkGraph {
+	g := &LinkGraph{
+		forward:  make(map[string][]string, len(allNotes)),
+		backward: make(map[string][]string, len(allNotes)),
+	}
+
+	for _, n := range allNotes {
+		links := parseLinks(n.Content)
+		g.forward[n.Title] = links
+		for _, target := range links {
+			g.backward[target] = append(g.backward[target], n.Title)
+		}
+	}
+
+	return g
+}
+
+// BrokenLink describes a [[link]] or markdown link whose target note does
+// not exist.
+type BrokenLink struct {
+	// Source is the title of the note containing the link.
+	Source string
+	// Target is the linked title that could not be resolved.
+	Target string
+}
+
+// Backlinks returns every note that links to title, via either
+// [[wiki-link]] or markdown syntax.
+func (s *Store) Backlinks(title string) ([]Note, error) {
+	allNotes, err := s.GetNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	byTitle := make(map[string]Note, len(allNotes))
+	for _, n := range allNotes {
+		byTitle[n.Title] = n
+	}
+
+	graph := buildLinkGraph(allNotes)
+
+	results := make([]Note, 0, len(graph.backward[title]))
+	for _, source := range graph.backward[title] {
+		if n, ok := byTitle[source]; ok {
+			results = append(results, n)
+		}
+	}
+	return results, nil
+}
+
+// BrokenLinks scans every note for links whose target note does not exist.
+func (s *Store) BrokenLinks() ([]BrokenLink, error) {
+	allNotes, err := s.GetNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	exists := make(map[string]bool, len(allNotes))
+	for _, n := range allNotes {
+		exists[n.Title] = true
+	}
+
+	var broken []BrokenLink
+	for _, n := range allNotes {
+		for _, target := range parseLinks(n.Content) {
+			if !exists[target] {
+				broken = append(broken, BrokenLink{Source: n.Title, Target: target})
+			}
+		}
+	}
+	return broken, nil
+}
+
+// rewriteInboundLinks rewrites every [[oldTitle]] reference across the
+// notebook to [[newTitle]], following Update's rename. Each file is
+// rewritten by writing to a temp file and renaming it into place, so a
+// crash mid-write can't corrupt a note.
+func (s *Store) rewriteInboundLinks(oldTitle, newTitle string) error {
+	entries, err := os.ReadDir(s.notesDir)
+	if err != nil {
+		return fmt.Errorf("error reading notes directory: %w", err)
+	}
+
+	pattern := regexp.MustCompile(`\[\[\s*` + regexp.QuoteMeta(oldTitle) + `\s*\]\]`)
+	replacement := []byte("[[" + newTitle + "]]")
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".md" {
+			continue
+		}
+
+		path := filepath.Join(s.notesDir, entry.Name())
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			continue // Skip files that vanished or can't be read
+		}
+		if !pattern.Match(raw) {
+			continue
+		}
+
+		tmpPath := path + ".tmp"
+		if err := os.WriteFile(tmpPath, pattern.ReplaceAll(raw, replacement), 0644); err != nil {
+			return fmt.Errorf("error writing %q: %w", tmpPath, err)
+		}
+		if err := os.Rename(tmpPath, path); err != nil {
+			return fmt.Errorf("error finalizing rewritten links in %q: %w", path, err)
+		}
+
+		if s.index != nil {
+			if note, err := s.Load(strings.TrimSuffix(entry.Name(), ".md")); err == nil {
+				if err := s.index.upsert(entry.Name(), *note, note.ModTime, note.Tags); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package notes
+
+import "testing"
+
+func TestBacklinks(t *testing.T) {
+	dir := t.TempDir()
+	s := &Store{}
+	if err := s.InitAt(dir); err != nil {
+		t.Fatalf("InitAt: %v", err)
+	}
+
+	if err := s.Save(Note{Title: "Target", Content: "the destination note"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := s.Save(Note{Title: "Source", Content: "see [[Target]] for details"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := s.Save(Note{Title: "Unrelated", Content: "no links here"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	backlinks, err := s.Backlinks("Target")
+	if err != nil {
+		t.Fatalf("Backlinks: %v", err)
+	}
+	if len(backlinks) != 1 || backlinks[0].Title != "Source" {
+		t.Fatalf("Backlinks: got %v, want [Source]", backlinks)
+	}
+}
+
+func TestBrokenLinks(t *testing.T) {
+	dir := t.TempDir()
+	s := &Store{}
+	if err := s.InitAt(dir); err != nil {
+		t.Fatalf("InitAt: %v", err)
+	}
+
+	if err := s.Save(Note{Title: "Source", Content: "links to [[Missing]]"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	broken, err := s.BrokenLinks()
+	if err != nil {
+		t.Fatalf("BrokenLinks: %v", err)
+	}
+	if len(broken) != 1 || broken[0].Source != "Source" || broken[0].Target != "Missing" {
+		t.Fatalf("BrokenLinks: got %v, want one broken link Source->Missing", broken)
+	}
+}
+
+func TestUpdateRewritesInboundLinks(t *testing.T) {
+	dir := t.TempDir()
+	s := &Store{}
+	if err := s.InitAt(dir); err != nil {
+		t.Fatalf("InitAt: %v", err)
+	}
+
+	if err := s.Save(Note{Title: "Target", Content: "the destination note"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := s.Save(Note{Title: "Source", Content: "see [[Target]] for details"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	newTitle := "Renamed"
+	if err := s.Update("Target", &newTitle, nil); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	source, err := s.Load("Source")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if source.Content != "see [[Renamed]] for details" {
+		t.Fatalf("inbound link not rewritten: got %q", source.Content)
+	}
+}
@@ -0,0 +1,117 @@
+package notes
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// NewNoteOpts configures NewNote.
+type NewNoteOpts struct {
+	Title   string
+	Content string
+	// Template is a path to a template file rendered in place of Content.
+	// Variables {{title}}, {{date}}, {{filename-stem}}, and
+	// {{extra.<key>}} are substituted before the body is written. Leave
+	// empty to use Content as the body; the same variables are substituted
+	// in Content.
+	Template string
+	Extra    map[string]string
+	// Date defaults to time.Now() when zero.
+	Date time.Time
+	// DryRun resolves the note's path and renders its body without writing
+	// anything to disk.
+	DryRun bool
+}
+
+// templateVarPattern matches {{var}} placeholders in a note body, including
+// dotted names like {{extra.project}}.
+var templateVarPattern = regexp.MustCompile(`\{\{\s*([\w.-]+)\s*\}\}`)
+
+// renderTemplateVars substitutes templateVarPattern placeholders in tmpl
+// using vars, leaving unknown placeholders untouched.
+func renderTemplateVars(tmpl string, vars map[string]string) string {
+	return templateVarPattern.ReplaceAllStringFunc(tmpl, func(match string) string {
+		key := templateVarPattern.FindStringSubmatch(match)[1]
+		if v, ok := vars[key]; ok {
+			return v
+		}
+		return match
+	})
+}
+
+// NewNote resolves a note's filename and body from opts, rendering the
+// body's placeholders (from a template file when one is given), and writes
+// the result to store's notebook. The filename is the sanitized title plus
+// ".md"; it is not rendered through the template variables. With DryRun set, it returns the resolved Note (Path and
+// Content populated) without touching the filesystem.
+func NewNote(store *Store, opts NewNoteOpts) (*Note, error) {
+	title := strings.TrimSpace(opts.Title)
+	if title == "" {
+		return nil, errors.New("note title cannot be empty")
+	}
+
+	date := opts.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	stem := sanitizeFilename(title)
+	filename := stem + ".md"
+	path := filepath.Join(store.notesDir, filename)
+
+	vars := map[string]string{
+		"title":         title,
+		"date":          date.Format("2006-01-02"),
+		"filename-stem": stem,
+	}
+	for k, v := range opts.Extra {
+		vars["extra."+k] = v
+	}
+
+	body := opts.Content
+	if opts.Template != "" {
+		tmplBytes, err := os.ReadFile(opts.Template)
+		if err != nil {
+			return nil, fmt.Errorf("error reading template %q: %w", opts.Template, err)
+		}
+		body = string(tmplBytes)
+	}
+	body = renderTemplateVars(body, vars)
+
+	tags := make([]string, 0, len(opts.Extra))
+	if tag, ok := opts.Extra["tags"]; ok {
+		tags = strings.Split(tag, ",")
+	}
+
+	fullBody := renderFrontMatter(title, date, tags) + body
+
+	note := &Note{
+		Title:       title,
+		Content:     fullBody,
+		ModTime:     date,
+		Path:        path,
+		Tags:        tags,
+		FrontMatter: map[string]any{"title": title, "created": date.Format(time.RFC3339)},
+	}
+
+	if opts.DryRun {
+		return note, nil
+	}
+
+	if err := os.WriteFile(path, []byte(fullBody), 0644); err != nil {
+		return nil, fmt.Errorf("error writing note %q: %w", path, err)
+	}
+
+	if store.index != nil {
+		if err := store.index.upsert(filename, *note, date, tags); err != nil {
+			return nil, fmt.Errorf("error indexing note %q: %w", title, err)
+		}
+	}
+
+	return note, nil
+}
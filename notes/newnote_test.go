@@ -0,0 +1,59 @@
+package notes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewNoteFrontMatterRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	s := &Store{}
+	if err := s.InitAt(dir); err != nil {
+		t.Fatalf("InitAt: %v", err)
+	}
+
+	created := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	note, err := NewNote(s, NewNoteOpts{
+		Title:   "Sourdough",
+		Content: "Mix flour and water.",
+		Extra:   map[string]string{"tags": "cooking,baking"},
+		Date:    created,
+	})
+	if err != nil {
+		t.Fatalf("NewNote: %v", err)
+	}
+
+	loaded, err := s.Load(note.Title)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	wantTags := []string{"cooking", "baking"}
+	if len(loaded.Tags) != len(wantTags) {
+		t.Fatalf("Load tags: got %v, want %v", loaded.Tags, wantTags)
+	}
+	for i, tag := range wantTags {
+		if loaded.Tags[i] != tag {
+			t.Fatalf("Load tags: got %v, want %v", loaded.Tags, wantTags)
+		}
+	}
+	if loaded.Content != "\nMix flour and water." {
+		t.Fatalf("Load content: got %q, want front matter stripped and body preserved", loaded.Content)
+	}
+}
+
+func TestNewNoteDryRunDoesNotWrite(t *testing.T) {
+	dir := t.TempDir()
+	s := &Store{}
+	if err := s.InitAt(dir); err != nil {
+		t.Fatalf("InitAt: %v", err)
+	}
+
+	if _, err := NewNote(s, NewNoteOpts{Title: "Draft", DryRun: true}); err != nil {
+		t.Fatalf("NewNote: %v", err)
+	}
+
+	if _, err := s.Load("Draft"); err == nil {
+		t.Fatalf("Load: expected error for a dry-run note that was never written")
+	}
+}
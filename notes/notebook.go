@@ -0,0 +1,89 @@
+package notes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// configDir is the per-notebook directory holding udon's configuration and
+// marking a directory as a notebook root.
+const configDir = ".udon"
+
+// configFilename is the TOML file read from within configDir.
+const configFilename = "config.toml"
+
+// NotebookConfig holds the per-notebook settings read from
+// .udon/config.toml. Any field left unset in the file keeps its default.
+type NotebookConfig struct {
+	// Templates maps a template name to a path (relative to the notebook
+	// root) of a template file used by NewNote.
+	Templates map[string]string `toml:"templates"`
+	// DefaultExt is a file extension, including the leading dot, read from
+	// the config. Defaults to ".md". NewNote does not use it: new notes
+	// are always written with ".md".
+	DefaultExt string `toml:"default_ext"`
+	// Ignore holds glob patterns read from the config. GetNotes and
+	// Reindex do not apply them.
+	Ignore []string `toml:"ignore"`
+	// GlamourStyle names a Glamour style, read from the config. Defaults to
+	// "dark". The TUI's previews are rendered with the "dark" style
+	// whatever this is set to.
+	GlamourStyle string `toml:"glamour_style"`
+}
+
+// defaultNotebookConfig returns the configuration used when a notebook has
+// no config.toml of its own.
+func defaultNotebookConfig() NotebookConfig {
+	return NotebookConfig{
+		DefaultExt:   ".md",
+		GlamourStyle: "dark",
+	}
+}
+
+// loadNotebookConfig reads .udon/config.toml under root, falling back to
+// defaultNotebookConfig if the file does not exist.
+func loadNotebookConfig(root string) (NotebookConfig, error) {
+	cfg := defaultNotebookConfig()
+
+	path := filepath.Join(root, configDir, configFilename)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return cfg, nil
+	}
+
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		return NotebookConfig{}, fmt.Errorf("error reading notebook config %q: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Notebook is a single notes collection: a root directory, its resolved
+// config, and the Store that reads and writes notes beneath it.
+type Notebook struct {
+	Name   string
+	Root   string
+	Config NotebookConfig
+	Store  *Store
+}
+
+// openNotebook loads a notebook's config and initializes its Store.
+func openNotebook(name, root string) (*Notebook, error) {
+	cfg, err := loadNotebookConfig(root)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &Store{}
+	if err := store.InitAt(root); err != nil {
+		return nil, fmt.Errorf("error opening notebook %q: %w", name, err)
+	}
+
+	return &Notebook{
+		Name:   name,
+		Root:   root,
+		Config: cfg,
+		Store:  store,
+	}, nil
+}
@@ -13,23 +13,11 @@ func LoadToMem(store *Store, title string) (*Note, error) {
 	return note, nil
 }
 
-// SearchNotes returns a slice of notes whose title or content contains the
-// given query string, case-insensitively. It retrieves all notes from the
-// provided Store and filters them based on the query. If an error occurs
-// while retrieving notes, it returns nil and the error.
+// SearchNotes returns notes whose title or content matches the given query,
+// using the Store's persistent index rather than scanning every note on
+// disk. An empty query returns every note.
 func SearchNotes(store *Store, query string) ([]Note, error) {
-	notes, err := store.GetNotes()
-	if err != nil {
-		return nil, err
-	}
-
-	var results []Note
-	for _, note := range notes {
-		if strings.Contains(strings.ToLower(note.Title), strings.ToLower(query)) || strings.Contains(strings.ToLower(note.Content), strings.ToLower(query)) {
-			results = append(results, note)
-		}
-	}
-	return results, nil
+	return store.Query(NoteFindOpts{Query: query})
 }
 
 // checkDuplicateName checks if a note with the given title already exists in the Store.
@@ -1,14 +1,13 @@
 package notes
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
+	"log"
 	"os"
 	"os/user"
 	"path/filepath"
 	"regexp"
-	"sort"
 	"strings"
 	"time"
 	"unicode"
@@ -19,11 +18,19 @@ type Note struct {
 	Title   string
 	Content string
 	ModTime time.Time
+
+	// Path is the note's absolute file path on disk.
+	Path string
+	// Tags and FrontMatter are populated from the note's YAML front matter,
+	// if any, when the note is loaded.
+	Tags        []string
+	FrontMatter map[string]any
 }
 
 // Store manages the storage and retrieval of notes from the filesystem.
 type Store struct {
 	notesDir string
+	index    *Index
 }
 
 // sanitizeFilename replaces invalid filename characters with underscores.
@@ -34,76 +41,49 @@ func sanitizeFilename(name string) string {
 	return invalidFilenameChars.ReplaceAllString(name, "_")
 }
 
-// Init initializes the storage directory. If the directory does not exist, it creates one.
+// Init initializes the default storage directory (~/Documents/udon). If the
+// directory does not exist, it creates one.
 func (s *Store) Init() error {
 	usr, err := user.Current()
 	if err != nil {
 		return fmt.Errorf("could not get current user: %w", err)
 	}
 
-	s.notesDir = filepath.Join(usr.HomeDir, "Documents", "udon")
+	return s.InitAt(filepath.Join(usr.HomeDir, "Documents", "udon"))
+}
+
+// InitAt initializes the storage directory at notesDir. If the directory
+// does not exist, it creates one. It is used by Init for the default
+// notebook and by Workspace to open additional notebooks rooted elsewhere.
+func (s *Store) InitAt(notesDir string) error {
+	s.notesDir = notesDir
 
 	if err := os.MkdirAll(s.notesDir, 0755); err != nil {
 		return fmt.Errorf("error creating note path: %w", err)
 	}
 
-	return nil
-}
-
-// GetNotes retrieves all notes from the storage directory.
-// It returns a slice of Note and any error encountered during retrieval.
-func (s *Store) GetNotes() ([]Note, error) {
-	entries, err := os.ReadDir(s.notesDir)
+	index, err := openIndex(filepath.Join(s.notesDir, indexFilename))
 	if err != nil {
-		return nil, fmt.Errorf("error reading notes directory: %w", err)
+		return err
 	}
-
-	var notes []Note
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		filename := entry.Name()
-		if filepath.Ext(filename) != ".md" {
-			continue // Only process .md files
-		}
-
-		name := strings.TrimSuffix(filename, ".md")
-		path := filepath.Join(s.notesDir, filename)
-
-		file, err := os.Open(path)
-		if err != nil {
-			// Skip files that cannot be opened, but continue processing others
-			continue
-		}
-
-		var content []string
-		scanner := bufio.NewScanner(file)
-		for scanner.Scan() {
-			content = append(content, scanner.Text())
-		}
-		file.Close()
-
-		info, err := entry.Info()
-		if err != nil {
-			continue // Skip files with unreadable metadata
-		}
-
-		note := Note{
-			Title:   name,
-			Content: strings.Join(content, "\n"),
-			ModTime: info.ModTime().Local(),
-		}
-		notes = append(notes, note)
+	s.index = index
+
+	// Reindex synchronously so the index reflects notesDir's current
+	// contents before InitAt returns: callers like NewModel list notes
+	// immediately afterward via GetNotes, which takes its list of notes
+	// from the index.
+	if err := s.Reindex(); err != nil {
+		return fmt.Errorf("error reindexing %q: %w", s.notesDir, err)
 	}
 
-	sort.Slice(notes, func(i, j int) bool {
-		return notes[i].ModTime.After(notes[j].ModTime)
-	})
+	return nil
+}
 
-	return notes, nil
+// GetNotes retrieves all notes, newest first. The list of notes comes from
+// the Store's persistent index rather than a scan of the notes directory;
+// each listed note is then loaded from disk.
+func (s *Store) GetNotes() ([]Note, error) {
+	return s.Query(NoteFindOpts{})
 }
 
 // Load retrieves a single note by title.
@@ -125,11 +105,18 @@ func (s *Store) Load(title string) (*Note, error) {
 		return nil, fmt.Errorf("error getting file info for %q: %w", title, err)
 	}
 
-	return &Note{
+	note := &Note{
 		Title:   title,
 		Content: string(content),
 		ModTime: info.ModTime().Local(),
-	}, nil
+		Path:    path,
+	}
+	if fm, body, err := splitFrontMatter(note.Content); err == nil && fm != nil {
+		note.FrontMatter = fm
+		note.Tags = tagsFromFrontMatter(fm)
+		note.Content = body
+	}
+	return note, nil
 }
 
 // Save writes the given note to the storage directory.
@@ -145,6 +132,19 @@ func (s *Store) Save(note Note) error {
 	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
 		return fmt.Errorf("could not save note: %w", err)
 	}
+
+	if s.index != nil {
+		saved := Note{Title: note.Title, Content: content, ModTime: time.Now()}
+		tags := note.Tags
+		if fm, body, err := splitFrontMatter(content); err == nil && fm != nil {
+			saved.Content = body
+			tags = tagsFromFrontMatter(fm)
+		}
+		if err := s.index.upsert(filename, saved, saved.ModTime, tags); err != nil {
+			log.Printf("failed to index %q: %v", note.Title, err)
+		}
+	}
+
 	return nil
 }
 
@@ -164,6 +164,13 @@ func (s *Store) Delete(noteName string) error {
 		}
 		return fmt.Errorf("error deleting note %q: %w", noteName, err)
 	}
+
+	if s.index != nil {
+		if err := s.index.remove(filename); err != nil {
+			log.Printf("failed to remove %q from index: %v", noteName, err)
+		}
+	}
+
 	return nil
 }
 
@@ -180,6 +187,7 @@ func (s *Store) Update(oldTitle string, updatedTitle *string, updatedContent *st
 	oldFilename := sanitizeFilename(strings.TrimSpace(oldTitle)) + ".md"
 	oldPath := filepath.Join(s.notesDir, oldFilename)
 	path := oldPath
+	filename := oldFilename
 
 	// Rename the note file if a new, non-empty title is provided.
 	if updatedTitle != nil && strings.TrimSpace(*updatedTitle) != "" {
@@ -189,6 +197,17 @@ func (s *Store) Update(oldTitle string, updatedTitle *string, updatedContent *st
 			return fmt.Errorf("error renaming %q to %q: %w", oldTitle, *updatedTitle, err)
 		}
 		path = newPath
+		filename = newFilename
+
+		if s.index != nil {
+			if err := s.index.remove(oldFilename); err != nil {
+				log.Printf("failed to remove %q from index: %v", oldTitle, err)
+			}
+		}
+
+		if err := s.rewriteInboundLinks(oldTitle, *updatedTitle); err != nil {
+			return fmt.Errorf("error rewriting links to %q: %w", oldTitle, err)
+		}
 	}
 
 	// Update the note content if new content is provided.
@@ -207,5 +226,15 @@ func (s *Store) Update(oldTitle string, updatedTitle *string, updatedContent *st
 		}
 	}
 
+	if s.index != nil {
+		title := strings.TrimSuffix(filename, ".md")
+		note, err := s.Load(title)
+		if err == nil {
+			if err := s.index.upsert(filename, *note, note.ModTime, note.Tags); err != nil {
+				log.Printf("failed to index %q: %v", title, err)
+			}
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,159 @@
+package notes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// EventType categorizes a change to a note file on disk.
+type EventType int
+
+const (
+	Created EventType = iota
+	Modified
+	Deleted
+	Renamed
+)
+
+// Event describes a single change to a note file, as reported by Watch.
+type Event struct {
+	Type  EventType
+	Title string
+	Path  string
+}
+
+// watchDebounce coalesces bursts of filesystem events (an editor's atomic
+// save, for instance, can fire several in quick succession) into one event
+// per file.
+const watchDebounce = 150 * time.Millisecond
+
+// Watch watches notesDir for changes to .md files and returns a channel of
+// coalesced Events. The channel is closed, and the underlying watcher
+// released, when ctx is done.
+//
+// Editors that save atomically (write a temp file, then rename it over the
+// destination) only ever touch the watched directory with that rename; from
+// fsnotify's point of view that arrives as a Create event for the
+// destination name, which Watch reports as EventType Created, same as a
+// brand new file.
+func (s *Store) Watch(ctx context.Context) (<-chan Event, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("error creating watcher: %w", err)
+	}
+	if err := watcher.Add(s.notesDir); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("error watching %q: %w", s.notesDir, err)
+	}
+
+	events := make(chan Event)
+
+	go func() {
+		defer watcher.Close()
+		defer close(events)
+
+		pending := make(map[string]Event)
+		var debounce *time.Timer
+		flush := make(chan struct{}, 1)
+
+		scheduleFlush := func() {
+			if debounce != nil {
+				debounce.Stop()
+			}
+			debounce = time.AfterFunc(watchDebounce, func() {
+				select {
+				case flush <- struct{}{}:
+				default:
+				}
+			})
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case fsEvent, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Ext(fsEvent.Name) != ".md" {
+					continue
+				}
+
+				var evType EventType
+				switch {
+				case fsEvent.Op&fsnotify.Create != 0:
+					evType = Created
+				case fsEvent.Op&fsnotify.Write != 0:
+					evType = Modified
+				case fsEvent.Op&fsnotify.Remove != 0:
+					evType = Deleted
+				case fsEvent.Op&fsnotify.Rename != 0:
+					evType = Renamed
+				default:
+					continue
+				}
+
+				title := strings.TrimSuffix(filepath.Base(fsEvent.Name), ".md")
+				pending[fsEvent.Name] = Event{Type: evType, Title: title, Path: fsEvent.Name}
+				scheduleFlush()
+
+			case <-flush:
+				s.reindexPending(pending)
+				for _, ev := range pending {
+					select {
+					case events <- ev:
+					case <-ctx.Done():
+						return
+					}
+				}
+				pending = make(map[string]Event)
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("watch error: %v", err)
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// reindexPending brings the index up to date with a batch of coalesced
+// filesystem events, so external edits don't require a full Reindex.
+func (s *Store) reindexPending(pending map[string]Event) {
+	if s.index == nil {
+		return
+	}
+
+	for path, ev := range pending {
+		filename := filepath.Base(path)
+
+		if ev.Type == Deleted || ev.Type == Renamed {
+			// A Renamed event names the old path, which no longer exists;
+			// treat it the same as a deletion so the stale row doesn't
+			// linger in the index.
+			if err := s.index.remove(filename); err != nil {
+				log.Printf("failed to remove %q from index: %v", filename, err)
+			}
+			continue
+		}
+
+		note, err := s.Load(ev.Title)
+		if err != nil {
+			continue // File vanished again before we got to it
+		}
+		if err := s.index.upsert(filename, *note, note.ModTime, note.Tags); err != nil {
+			log.Printf("failed to index %q: %v", ev.Title, err)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package notes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReindexPendingRemovesRenamedEntry(t *testing.T) {
+	dir := t.TempDir()
+
+	idx, err := openIndex(filepath.Join(dir, indexFilename))
+	if err != nil {
+		t.Fatalf("openIndex: %v", err)
+	}
+	defer idx.Close()
+	s := &Store{notesDir: dir, index: idx}
+
+	oldPath := filepath.Join(dir, "old-name.md")
+	if err := os.WriteFile(oldPath, []byte("content"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	note, err := s.Load("old-name")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := idx.upsert("old-name.md", *note, note.ModTime, nil); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+
+	// Simulate the rename away: the old file is gone by the time the event
+	// is processed, same as fsnotify reporting a Renamed event for the
+	// source path of a move.
+	if err := os.Remove(oldPath); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+
+	s.reindexPending(map[string]Event{
+		oldPath: {Type: Renamed, Title: "old-name", Path: oldPath},
+	})
+
+	if _, ok, err := idx.mtime("old-name.md"); err != nil {
+		t.Fatalf("mtime: %v", err)
+	} else if ok {
+		t.Fatalf("expected renamed-away entry to be removed from the index, but it still exists")
+	}
+}
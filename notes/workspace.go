@@ -0,0 +1,91 @@
+package notes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Workspace registers and opens several notebooks over the lifetime of a
+// single udon process, so the TUI and LSP server can switch between them
+// without restarting.
+type Workspace struct {
+	mu        sync.RWMutex
+	notebooks map[string]*Notebook
+}
+
+// NewWorkspace returns an empty Workspace ready to register notebooks into.
+func NewWorkspace() *Workspace {
+	return &Workspace{notebooks: make(map[string]*Notebook)}
+}
+
+// Register opens the notebook rooted at root and adds it to the workspace
+// under name, replacing any existing notebook with that name.
+func (w *Workspace) Register(name, root string) (*Notebook, error) {
+	nb, err := openNotebook(name, root)
+	if err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	w.notebooks[name] = nb
+	w.mu.Unlock()
+
+	return nb, nil
+}
+
+// Notebook returns the registered notebook with the given name.
+func (w *Workspace) Notebook(name string) (*Notebook, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	nb, ok := w.notebooks[name]
+	if !ok {
+		return nil, fmt.Errorf("no notebook registered with name %q", name)
+	}
+	return nb, nil
+}
+
+// Names returns the names of every notebook currently registered, in no
+// particular order.
+func (w *Workspace) Names() []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	names := make([]string, 0, len(w.notebooks))
+	for name := range w.notebooks {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Locate walks upward from cwd looking for a ".udon" marker directory. When
+// found, it registers (or returns the already-registered) notebook rooted
+// there, so invoking udon from inside a subfolder attaches to the right
+// notebook.
+func (w *Workspace) Locate(cwd string) (*Notebook, error) {
+	dir, err := filepath.Abs(cwd)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving %q: %w", cwd, err)
+	}
+
+	for {
+		info, err := os.Stat(filepath.Join(dir, configDir))
+		if err == nil && info.IsDir() {
+			name := filepath.Base(dir)
+			if nb, err := w.Notebook(name); err == nil {
+				return nb, nil
+			}
+			return w.Register(name, dir)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return nil, fmt.Errorf("no %s notebook found above %q", configDir, cwd)
+}
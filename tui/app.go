@@ -15,3 +15,12 @@ func Run(store *notes.Store) error {
 	_, err := p.Run()
 	return err
 }
+
+// RunWithWorkspace sets up and starts the Bubbletea TUI program against a
+// Workspace, with active as the initially selected notebook.
+func RunWithWorkspace(workspace *notes.Workspace, active string) error {
+	m := NewModelWithWorkspace(workspace, active)
+	p := tea.NewProgram(m)
+	_, err := p.Run()
+	return err
+}
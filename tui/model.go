@@ -1,7 +1,11 @@
 package tui
 
 import (
+	"context"
 	"log"
+	"path/filepath"
+	"sort"
+	"strings"
 	"udon/notes"
 
 	"github.com/charmbracelet/bubbles/textarea"
@@ -13,23 +17,131 @@ const (
 	listView uint = iota
 	editorView
 	previewView
+	fuzzyView
+	newNoteView
+	backlinksView
 )
 
 type model struct {
-	state     uint
-	store     *notes.Store
-	notes     []notes.Note
-	currNote  notes.Note
-	statusMsg string
-	listIndex int
-	textarea  textarea.Model
-	textinput textinput.Model
-	width     int
-	height    int
+	state      uint
+	store      *notes.Store
+	notes      []notes.Note
+	currNote   notes.Note
+	statusMsg  string
+	listIndex  int
+	searching  bool
+	matches    []notes.Match
+	matchIndex int
+	textarea   textarea.Model
+	textinput  textinput.Model
+	width      int
+	height     int
+
+	// workspace and notebooks are nil/empty when the TUI is running against
+	// a single, non-workspace Store (see NewModel).
+	workspace     *notes.Workspace
+	notebooks     []string
+	notebookIndex int
+
+	// New-note flow state (see the "n" binding in listView).
+	templates      []string
+	templateIndex  int
+	newNotePreview *notes.Note
+	confirmingNew  bool
+
+	// backlinks are the notes linking to m.notes[m.listIndex], shown in
+	// backlinksView (see the "b" binding in listView).
+	backlinks     []notes.Note
+	backlinkIndex int
+
+	// watchEvents streams external changes to the active notebook's notes
+	// directory; watchCancel stops the watcher behind it.
+	watchEvents <-chan notes.Event
+	watchCancel context.CancelFunc
+}
+
+// queryResultMsg carries the notes matching the in-progress search, or an
+// error if the index query failed.
+type queryResultMsg struct {
+	notes []notes.Note
+	err   error
+}
+
+// queryCmd asks the Store's index for notes matching query and wraps the
+// result in a queryResultMsg.
+func queryCmd(store *notes.Store, query string) tea.Cmd {
+	return func() tea.Msg {
+		results, err := notes.SearchNotes(store, query)
+		return queryResultMsg{notes: results, err: err}
+	}
+}
+
+// filterResultMsg carries the ranked fuzzy matches for the in-progress
+// fuzzy-finder query, or an error if retrieving notes failed.
+type filterResultMsg struct {
+	matches []notes.Match
+	err     error
+}
+
+// filterCmd fuzzy-matches query against every note's title and content and
+// wraps the ranked result in a filterResultMsg.
+func filterCmd(store *notes.Store, query string) tea.Cmd {
+	return func() tea.Msg {
+		matches, err := notes.FilterNotes(store, query, notes.FilterOpts{})
+		return filterResultMsg{matches: matches, err: err}
+	}
+}
+
+// backlinksResultMsg carries the notes linking to the note the user
+// toggled backlinksView for, or an error if retrieving them failed.
+type backlinksResultMsg struct {
+	notes []notes.Note
+	err   error
+}
+
+// backlinksCmd looks up every note that links to title.
+func backlinksCmd(store *notes.Store, title string) tea.Cmd {
+	return func() tea.Msg {
+		results, err := store.Backlinks(title)
+		return backlinksResultMsg{notes: results, err: err}
+	}
 }
 
 func (m model) Init() tea.Cmd {
-	return nil
+	if m.watchEvents == nil {
+		return nil
+	}
+	return waitForWatchEvent(m.watchEvents)
+}
+
+// fsEventMsg wraps a notes.Event from Store.Watch for the Bubbletea event
+// loop.
+type fsEventMsg notes.Event
+
+// waitForWatchEvent blocks on events and wraps the next one as a tea.Msg.
+// Update re-issues this command after each event so the model keeps
+// listening for the rest of the channel's lifetime.
+func waitForWatchEvent(events <-chan notes.Event) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-events
+		if !ok {
+			return nil
+		}
+		return fsEventMsg(ev)
+	}
+}
+
+// startWatch begins watching store's notebook for external changes,
+// returning the event channel the model listens on.
+func startWatch(store *notes.Store) (<-chan notes.Event, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := store.Watch(ctx)
+	if err != nil {
+		log.Printf("failed to watch notes directory: %v", err)
+		cancel()
+		return nil, nil
+	}
+	return events, cancel
 }
 
 func NewModel(store *notes.Store) model {
@@ -37,13 +149,110 @@ func NewModel(store *notes.Store) model {
 	if err != nil {
 		log.Fatalf("Error retrieving notes: %v", err)
 	}
+
+	events, cancel := startWatch(store)
+
 	return model{
-		state:     listView,
-		store:     store,
-		notes:     notesSlice,
-		textarea:  textarea.New(),
-		textinput: textinput.New(),
+		state:       listView,
+		store:       store,
+		notes:       notesSlice,
+		textarea:    textarea.New(),
+		textinput:   textinput.New(),
+		watchEvents: events,
+		watchCancel: cancel,
+	}
+}
+
+// NewModelWithWorkspace builds a model whose active Store can be swapped
+// between every notebook registered in workspace via the "w" keybinding in
+// listView.
+func NewModelWithWorkspace(workspace *notes.Workspace, active string) model {
+	names := workspace.Names()
+	sort.Strings(names)
+
+	nb, err := workspace.Notebook(active)
+	if err != nil {
+		log.Fatalf("Error opening notebook %q: %v", active, err)
+	}
+
+	m := NewModel(nb.Store)
+	m.workspace = workspace
+	m.notebooks = names
+	m.templates = templateNames(nb)
+	for i, name := range names {
+		if name == active {
+			m.notebookIndex = i
+		}
+	}
+	return m
+}
+
+// templateNames returns the sorted template names configured for notebook.
+func templateNames(nb *notes.Notebook) []string {
+	names := make([]string, 0, len(nb.Config.Templates))
+	for name := range nb.Config.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// switchNotebook advances to the next registered notebook, reloads the note
+// list from its Store, and starts watching its notebook directory in place
+// of the previous one.
+func (m model) switchNotebook() (model, tea.Cmd) {
+	if len(m.notebooks) == 0 {
+		return m, nil
+	}
+
+	m.notebookIndex = (m.notebookIndex + 1) % len(m.notebooks)
+	nb, err := m.workspace.Notebook(m.notebooks[m.notebookIndex])
+	if err != nil {
+		m.statusMsg = "Error switching notebook: " + err.Error()
+		return m, nil
+	}
+
+	notesSlice, err := nb.Store.GetNotes()
+	if err != nil {
+		m.statusMsg = "Error loading notebook: " + err.Error()
+		return m, nil
+	}
+
+	if m.watchCancel != nil {
+		m.watchCancel()
+	}
+	events, cancel := startWatch(nb.Store)
+
+	m.store = nb.Store
+	m.notes = notesSlice
+	m.listIndex = 0
+	m.templates = templateNames(nb)
+	m.templateIndex = 0
+	m.watchEvents = events
+	m.watchCancel = cancel
+	m.statusMsg = "Switched to notebook " + nb.Name
+
+	if events == nil {
+		return m, nil
+	}
+	return m, waitForWatchEvent(events)
+}
+
+// activeTemplatePath returns the template file configured for the currently
+// selected template choice, or "" if no notebook/templates are configured.
+func (m model) activeTemplatePath() string {
+	if m.workspace == nil || len(m.templates) == 0 {
+		return ""
+	}
+	nb, err := m.workspace.Notebook(m.notebooks[m.notebookIndex])
+	if err != nil {
+		return ""
+	}
+	path := nb.Config.Templates[m.templates[m.templateIndex]]
+	if path != "" && !filepath.IsAbs(path) {
+		path = filepath.Join(nb.Root, path)
 	}
+	return path
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -60,6 +269,38 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+	case fsEventMsg:
+		if notesSlice, err := m.store.GetNotes(); err == nil {
+			m.notes = notesSlice
+			if m.listIndex >= len(m.notes) {
+				m.listIndex = len(m.notes) - 1
+			}
+			if m.listIndex < 0 {
+				m.listIndex = 0
+			}
+		}
+		cmds = append(cmds, waitForWatchEvent(m.watchEvents))
+	case queryResultMsg:
+		if msg.err != nil {
+			m.statusMsg = "Search error: " + msg.err.Error()
+		} else {
+			m.notes = msg.notes
+			m.listIndex = 0
+		}
+	case filterResultMsg:
+		if msg.err != nil {
+			m.statusMsg = "Filter error: " + msg.err.Error()
+		} else {
+			m.matches = msg.matches
+			m.matchIndex = 0
+		}
+	case backlinksResultMsg:
+		if msg.err != nil {
+			m.statusMsg = "Backlinks error: " + msg.err.Error()
+		} else {
+			m.backlinks = msg.notes
+			m.backlinkIndex = 0
+		}
 	case tea.KeyMsg:
 		key := msg.String()
 		switch m.state {
@@ -71,9 +312,51 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.state = listView
 			}
 		case listView:
+			if m.searching {
+				switch key {
+				case "esc":
+					m.searching = false
+					m.textinput.Blur()
+					m.textinput.SetValue("")
+					cmds = append(cmds, queryCmd(m.store, ""))
+				case "enter":
+					m.searching = false
+					m.textinput.Blur()
+				default:
+					cmds = append(cmds, queryCmd(m.store, m.textinput.Value()))
+				}
+				break
+			}
 			switch key {
 			case "q", "ctrl+c":
 				return m, tea.Quit
+			case "/":
+				m.searching = true
+				m.textinput.Focus()
+				cmds = append(cmds, textinput.Blink)
+			case "f":
+				m.state = fuzzyView
+				m.textinput.SetValue("")
+				m.textinput.Focus()
+				cmds = append(cmds, textinput.Blink, filterCmd(m.store, ""))
+			case "w":
+				var switchCmd tea.Cmd
+				m, switchCmd = m.switchNotebook()
+				cmds = append(cmds, switchCmd)
+			case "n":
+				m.state = newNoteView
+				m.confirmingNew = false
+				m.newNotePreview = nil
+				m.templateIndex = 0
+				m.textinput.SetValue("")
+				m.textinput.Focus()
+				cmds = append(cmds, textinput.Blink)
+			case "b":
+				if len(m.notes) == 0 {
+					break
+				}
+				m.state = backlinksView
+				cmds = append(cmds, backlinksCmd(m.store, m.notes[m.listIndex].Title))
 			case "k", "up":
 				if m.listIndex > 0 {
 					m.listIndex--
@@ -100,6 +383,132 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.state = editorView
 				m.textarea.Focus()
 			}
+		case fuzzyView:
+			switch key {
+			case "esc":
+				m.state = listView
+				m.textinput.Blur()
+				m.textinput.SetValue("")
+			case "ctrl+c":
+				return m, tea.Quit
+			case "up", "ctrl+p":
+				if m.matchIndex > 0 {
+					m.matchIndex--
+				}
+			case "down":
+				if m.matchIndex < len(m.matches)-1 {
+					m.matchIndex++
+				}
+			case "ctrl+n":
+				query := strings.TrimSpace(m.textinput.Value())
+				if query == "" {
+					break
+				}
+				note := notes.Note{Title: query}
+				if err := m.store.Save(note); err != nil {
+					m.statusMsg = "Error creating note: " + err.Error()
+					break
+				}
+				m.currNote = note
+				m.textarea.SetValue("")
+				m.state = editorView
+				m.textarea.Focus()
+			case "enter":
+				if len(m.matches) == 0 {
+					break
+				}
+				notePtr, err := notes.LoadToMem(m.store, m.matches[m.matchIndex].Note.Title)
+				if err != nil {
+					m.statusMsg = "Error loading note: " + err.Error()
+				} else if notePtr != nil {
+					m.currNote = *notePtr
+					m.textarea.SetValue(m.currNote.Content)
+					m.state = editorView
+					m.textarea.Focus()
+				}
+			default:
+				cmds = append(cmds, filterCmd(m.store, m.textinput.Value()))
+			}
+		case newNoteView:
+			switch key {
+			case "esc":
+				m.state = listView
+				m.textinput.Blur()
+				m.textinput.SetValue("")
+			case "ctrl+c":
+				return m, tea.Quit
+			case "tab":
+				if len(m.templates) > 0 {
+					m.templateIndex = (m.templateIndex + 1) % len(m.templates)
+					m.confirmingNew = false
+					m.newNotePreview = nil
+				}
+			case "enter":
+				if !m.confirmingNew {
+					title := strings.TrimSpace(m.textinput.Value())
+					if title == "" {
+						break
+					}
+					preview, err := notes.NewNote(m.store, notes.NewNoteOpts{
+						Title:    title,
+						Template: m.activeTemplatePath(),
+						DryRun:   true,
+					})
+					if err != nil {
+						m.statusMsg = "Error previewing note: " + err.Error()
+						break
+					}
+					m.newNotePreview = preview
+					m.confirmingNew = true
+					break
+				}
+
+				note, err := notes.NewNote(m.store, notes.NewNoteOpts{
+					Title:    strings.TrimSpace(m.textinput.Value()),
+					Template: m.activeTemplatePath(),
+				})
+				if err != nil {
+					m.statusMsg = "Error creating note: " + err.Error()
+					break
+				}
+				m.currNote = *note
+				m.textarea.SetValue(note.Content)
+				m.state = editorView
+				m.textarea.Focus()
+				m.confirmingNew = false
+				m.newNotePreview = nil
+			default:
+				m.confirmingNew = false
+				m.newNotePreview = nil
+			}
+		case backlinksView:
+			switch key {
+			case "esc", "q":
+				m.state = listView
+			case "ctrl+c":
+				return m, tea.Quit
+			case "k", "up":
+				if m.backlinkIndex > 0 {
+					m.backlinkIndex--
+				}
+			case "j", "down":
+				if m.backlinkIndex < len(m.backlinks)-1 {
+					m.backlinkIndex++
+				}
+			case "enter", "l":
+				if len(m.backlinks) == 0 {
+					break
+				}
+				notePtr, err := notes.LoadToMem(m.store, m.backlinks[m.backlinkIndex].Title)
+				if err != nil {
+					m.statusMsg = "Error loading note: " + err.Error()
+				} else if notePtr != nil {
+					m.currNote = *notePtr
+					m.textarea.SetValue(m.currNote.Content)
+					m.state = editorView
+					m.textarea.Focus()
+				}
+			}
 		}
 	}
 	return m, tea.Batch(cmds...)
@@ -3,6 +3,7 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"udon/notes"
 
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
@@ -32,6 +33,12 @@ var editorStyle = lipgloss.NewStyle().
 	Padding(2, 1).
 	MarginRight(1)
 
+// matchHighlightStyle renders the fuzzy-matched runes within a fuzzyView
+// result line.
+var matchHighlightStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color(gruvYellow)).
+	Bold(true)
+
 // glamourRenderer is a reusable Glamour renderer with Gruvbox theming.
 var glamourRenderer = func() *glamour.TermRenderer {
 	r, _ := glamour.NewTermRenderer(
@@ -41,6 +48,41 @@ var glamourRenderer = func() *glamour.TermRenderer {
 	return r
 }()
 
+// renderPreview glamour-renders content, falling back to the raw text if
+// rendering fails.
+func renderPreview(content string) string {
+	rendered, err := glamourRenderer.Render(content)
+	if err != nil {
+		return content
+	}
+	return rendered
+}
+
+// highlightMatch renders a fuzzy match's title, bolding the runes that
+// matched the search query. Matches found in a note's body rather than its
+// title are shown unhighlighted, since the matched indexes refer to body
+// offsets rather than title offsets.
+func highlightMatch(m notes.Match) string {
+	if m.MatchedInBody || len(m.MatchedIndexes) == 0 {
+		return m.Note.Title
+	}
+
+	matched := make(map[int]bool, len(m.MatchedIndexes))
+	for _, idx := range m.MatchedIndexes {
+		matched[idx] = true
+	}
+
+	var b strings.Builder
+	for i, r := range m.Note.Title {
+		if matched[i] {
+			b.WriteString(matchHighlightStyle.Render(string(r)))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
 // View renders the main TUI layout with a split-pane design.
 // The left third of the terminal is the notes list, and the right two-thirds
 // is the editor or preview pane. The layout is responsive to terminal resizing.
@@ -53,12 +95,47 @@ func (m model) View() string {
 	statusWidth := m.width - statusStyle.GetHorizontalFrameSize()
 
 	var listContent strings.Builder
-	for i, n := range m.notes {
-		prefix := "  "
-		if i == m.listIndex {
-			prefix = "➤ "
+	if m.state == newNoteView {
+		listContent.WriteString("Title: " + m.textinput.View() + "\n\n")
+		if len(m.templates) > 0 {
+			listContent.WriteString("Template: " + m.templates[m.templateIndex] + " (tab to cycle)\n")
+		} else {
+			listContent.WriteString("Template: (none configured)\n")
+		}
+		if m.confirmingNew {
+			listContent.WriteString("\nenter again to save, esc to cancel\n")
+		} else {
+			listContent.WriteString("\nenter to preview, esc to cancel\n")
+		}
+	} else if m.state == backlinksView {
+		listContent.WriteString("Backlinks to " + m.notes[m.listIndex].Title + "\n\n")
+		if len(m.backlinks) == 0 {
+			listContent.WriteString("  (none)\n")
+		}
+		for i, n := range m.backlinks {
+			prefix := "  "
+			if i == m.backlinkIndex {
+				prefix = "➤ "
+			}
+			listContent.WriteString(prefix + n.Title + "\n")
+		}
+	} else if m.state == fuzzyView {
+		listContent.WriteString("Find: " + m.textinput.View() + "\n\n")
+		for i, match := range m.matches {
+			prefix := "  "
+			if i == m.matchIndex {
+				prefix = "➤ "
+			}
+			listContent.WriteString(prefix + highlightMatch(match) + "\n")
+		}
+	} else {
+		for i, n := range m.notes {
+			prefix := "  "
+			if i == m.listIndex {
+				prefix = "➤ "
+			}
+			listContent.WriteString(prefix + n.Title + "\n")
 		}
-		listContent.WriteString(prefix + n.Title + "\n")
 	}
 	listPane := listStyle.Width(listWidth).Render(listContent.String())
 
@@ -66,14 +143,27 @@ func (m model) View() string {
 	switch m.state {
 	case editorView:
 		editorContent = m.textarea.View()
+	case newNoteView:
+		if m.newNotePreview != nil {
+			editorContent = fmt.Sprintf("%s\n\n%s", m.newNotePreview.Path, renderPreview(m.newNotePreview.Content))
+		} else {
+			editorContent = "Type a title and press enter to preview."
+		}
+	case backlinksView:
+		if len(m.backlinks) > 0 {
+			editorContent = renderPreview(m.backlinks[m.backlinkIndex].Content)
+		} else {
+			editorContent = "No notes link here yet."
+		}
+	case fuzzyView:
+		if len(m.matches) > 0 {
+			editorContent = renderPreview(m.matches[m.matchIndex].Note.Content)
+		} else {
+			editorContent = "No matches."
+		}
 	default:
 		if len(m.notes) > 0 {
-			rendered, err := glamourRenderer.Render(m.notes[m.listIndex].Content)
-			if err != nil {
-				editorContent = m.notes[m.listIndex].Content
-			} else {
-				editorContent = rendered
-			}
+			editorContent = renderPreview(m.notes[m.listIndex].Content)
 		} else {
 			editorContent = "Select a note to preview/edit."
 		}